@@ -0,0 +1,36 @@
+// Package archetype classifies a user's goal text into one of a small set
+// of known prompt archetypes (summarization, question answering, ...) so
+// main.go can offer archetype-specific guidance and enrichment. Multiple
+// classifier backends are available behind the Classifier interface.
+package archetype
+
+// Archetype identifies the kind of task a goal is asking for.
+type Archetype string
+
+const (
+	Summarization  Archetype = "Summarization"
+	QA             Archetype = "QuestionAnswering"
+	Classification Archetype = "Classification"
+	Extraction     Archetype = "Extraction"
+	Rewriting      Archetype = "Rewriting"
+	CodeGeneration Archetype = "CodeGeneration"
+	Translation    Archetype = "Translation"
+	Unknown        Archetype = "Unknown"
+)
+
+// All lists every known archetype other than Unknown, in the order they
+// should be offered to a user (e.g. in an override select).
+var All = []Archetype{
+	Summarization,
+	QA,
+	Classification,
+	Extraction,
+	Rewriting,
+	CodeGeneration,
+	Translation,
+}
+
+// Classifier assigns an Archetype to a goal string.
+type Classifier interface {
+	Classify(goal string) (Archetype, error)
+}
@@ -0,0 +1,69 @@
+// Package corpus embeds the small labeled example set used to train the
+// TF-IDF archetype classifier. Each file holds one example goal per line,
+// and its name (minus extension) is the archetype label.
+package corpus
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed *.txt
+var files embed.FS
+
+// labels lists the file (and archetype label) names, in a stable order.
+var labels = []string{
+	"summarization",
+	"questionanswering",
+	"classification",
+	"extraction",
+	"rewriting",
+	"codegeneration",
+	"translation",
+}
+
+// Load reads the embedded corpus into a map of archetype label (matching
+// archetype.Archetype's string form) to example goal strings.
+func Load() (map[string][]string, error) {
+	examples := make(map[string][]string, len(labels))
+	for _, label := range labels {
+		data, err := files.ReadFile(label + ".txt")
+		if err != nil {
+			return nil, fmt.Errorf("corpus: read %s.txt: %w", label, err)
+		}
+		var lines []string
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+		examples[labelToArchetype(label)] = lines
+	}
+	return examples, nil
+}
+
+// labelToArchetype maps a corpus file's base name to its archetype.Archetype
+// string value. Kept as plain strings here to avoid an import cycle with
+// the archetype package, which imports corpus.
+func labelToArchetype(label string) string {
+	switch label {
+	case "summarization":
+		return "Summarization"
+	case "questionanswering":
+		return "QuestionAnswering"
+	case "classification":
+		return "Classification"
+	case "extraction":
+		return "Extraction"
+	case "rewriting":
+		return "Rewriting"
+	case "codegeneration":
+		return "CodeGeneration"
+	case "translation":
+		return "Translation"
+	default:
+		return label
+	}
+}
@@ -0,0 +1,56 @@
+package archetype
+
+import "strings"
+
+type keywordSet struct {
+	archetype Archetype
+	keywords  []string
+}
+
+// keywordSets are checked in order, so earlier archetypes take priority on
+// overlapping phrasing.
+var keywordSets = []keywordSet{
+	{QA, []string{"what is", "explain", "how does", "list", "compare", "does it", "can i", "where", "who", "when", "why"}},
+	{Summarization, []string{"summarize", "summary", "overview", "tldr", "key points", "abstract", "give me the gist"}},
+	{Translation, []string{"translate", "translation", "in spanish", "in french", "into english", "localize"}},
+	{CodeGeneration, []string{"write a function", "write code", "implement", "generate a script", "refactor this code"}},
+	{Extraction, []string{"extract", "pull out", "find all", "identify the", "list every"}},
+	{Classification, []string{"classify", "categorize", "label this", "is this spam", "which category"}},
+	{Rewriting, []string{"rewrite", "rephrase", "reword", "paraphrase", "make this more"}},
+}
+
+// keywordClassifier is the original brittle substring matcher, extended to
+// cover every known archetype.
+type keywordClassifier struct{}
+
+// NewKeyword returns a Classifier that matches goal text against a fixed
+// set of keywords per archetype.
+func NewKeyword() Classifier {
+	return &keywordClassifier{}
+}
+
+func (k *keywordClassifier) Classify(goal string) (Archetype, error) {
+	lowerGoal := strings.ToLower(goal)
+	for _, set := range keywordSets {
+		for _, keyword := range set.keywords {
+			if strings.Contains(lowerGoal, keyword) {
+				return set.archetype, nil
+			}
+		}
+	}
+	return Unknown, nil
+}
+
+// Keywords returns every keyword known to the keyword classifier, mapped
+// to the archetype it signals. main.go matches goal tokens against these
+// with internal/suggest to offer "did you mean?" corrections when
+// detection comes back Unknown.
+func Keywords() map[string]Archetype {
+	keywords := make(map[string]Archetype)
+	for _, set := range keywordSets {
+		for _, keyword := range set.keywords {
+			keywords[keyword] = set.archetype
+		}
+	}
+	return keywords
+}
@@ -0,0 +1,65 @@
+package archetype
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MasonBrott/prompttogo/providers"
+)
+
+// llmClassifier asks a provider to label a goal with a few-shot prompt,
+// for cases where keyword and TF-IDF matching are too coarse.
+type llmClassifier struct {
+	provider providers.Provider
+}
+
+// NewLLM returns a Classifier that delegates to provider with a few-shot
+// prompt listing every known archetype.
+func NewLLM(provider providers.Provider) Classifier {
+	return &llmClassifier{provider: provider}
+}
+
+func (c *llmClassifier) Classify(goal string) (Archetype, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	prompt := providers.Prompt{Goal: fewShotPrompt(goal)}
+	stream, err := c.provider.Complete(ctx, prompt)
+	if err != nil {
+		return Unknown, fmt.Errorf("archetype: llm classify: %w", err)
+	}
+
+	var sb strings.Builder
+	for token := range stream {
+		sb.WriteString(token)
+	}
+
+	label := strings.TrimSpace(sb.String())
+	for _, archetype := range All {
+		if strings.EqualFold(label, string(archetype)) {
+			return archetype, nil
+		}
+	}
+	return Unknown, nil
+}
+
+// fewShotPrompt builds a classification prompt listing every known
+// archetype, showing a few labeled example goals, and asking for exactly
+// one label back.
+func fewShotPrompt(goal string) string {
+	var labels []string
+	for _, a := range All {
+		labels = append(labels, string(a))
+	}
+	return fmt.Sprintf(
+		"Classify the following goal into exactly one of these labels: %s.\n"+
+			"Respond with only the label, nothing else.\n\n"+
+			"Goal: Summarize this article in one paragraph\nLabel: Summarization\n\n"+
+			"Goal: Translate this email into Portuguese\nLabel: Translation\n\n"+
+			"Goal: Extract all the dates mentioned in this contract\nLabel: Extraction\n\n"+
+			"Goal: %s\nLabel:",
+		strings.Join(labels, ", "), goal,
+	)
+}
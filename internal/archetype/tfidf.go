@@ -0,0 +1,166 @@
+package archetype
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/MasonBrott/prompttogo/internal/archetype/corpus"
+)
+
+// DefaultConfidenceThreshold is the minimum cosine similarity a TF-IDF
+// classification must clear to be returned instead of Unknown.
+const DefaultConfidenceThreshold = 0.1
+
+var tokenPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+var stopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "is": true, "are": true, "to": true,
+	"of": true, "in": true, "on": true, "for": true, "and": true, "or": true,
+	"this": true, "that": true, "it": true, "with": true, "as": true,
+}
+
+// tokenize lowercases s, splits on non-alphanumeric runs, and drops
+// stopwords.
+func tokenize(s string) []string {
+	var tokens []string
+	for _, tok := range tokenPattern.Split(strings.ToLower(s), -1) {
+		if tok == "" || stopwords[tok] {
+			continue
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+type vector map[string]float64
+
+func (v vector) norm() float64 {
+	var sum float64
+	for _, w := range v {
+		sum += w * w
+	}
+	return math.Sqrt(sum)
+}
+
+func (v vector) normalize() vector {
+	n := v.norm()
+	if n == 0 {
+		return v
+	}
+	out := make(vector, len(v))
+	for t, w := range v {
+		out[t] = w / n
+	}
+	return out
+}
+
+func cosineSimilarity(a, b vector) float64 {
+	var dot float64
+	small, large := a, b
+	if len(b) < len(a) {
+		small, large = b, a
+	}
+	for t, w := range small {
+		dot += w * large[t]
+	}
+	return dot
+}
+
+// tfidfClassifier classifies goals by cosine similarity against per-class
+// centroid vectors built from internal/archetype/corpus.
+type tfidfClassifier struct {
+	centroids map[Archetype]vector
+	df        map[string]int
+	n         int
+	threshold float64
+}
+
+// NewTFIDF trains a Classifier on the embedded corpus. threshold is the
+// minimum cosine similarity required to return a non-Unknown archetype;
+// pass 0 to use DefaultConfidenceThreshold.
+func NewTFIDF(threshold float64) (Classifier, error) {
+	if threshold <= 0 {
+		threshold = DefaultConfidenceThreshold
+	}
+
+	examples, err := corpus.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	// Document frequency across the whole corpus.
+	df := make(map[string]int)
+	docTokens := make(map[Archetype][][]string, len(examples))
+	for label, goals := range examples {
+		archetype := Archetype(label)
+		for _, goal := range goals {
+			tokens := tokenize(goal)
+			docTokens[archetype] = append(docTokens[archetype], tokens)
+			seen := make(map[string]bool)
+			for _, t := range tokens {
+				if !seen[t] {
+					df[t]++
+					seen[t] = true
+				}
+			}
+		}
+	}
+
+	n := 0
+	for _, goals := range docTokens {
+		n += len(goals)
+	}
+
+	centroids := make(map[Archetype]vector, len(docTokens))
+	for archetype, docs := range docTokens {
+		centroid := make(vector)
+		for _, tokens := range docs {
+			tf := make(map[string]int)
+			for _, t := range tokens {
+				tf[t]++
+			}
+			for t, count := range tf {
+				weight := (1 + math.Log(float64(count))) * math.Log(float64(n)/float64(df[t]))
+				centroid[t] += weight
+			}
+		}
+		centroids[archetype] = centroid.normalize()
+	}
+
+	return &tfidfClassifier{centroids: centroids, df: df, n: n, threshold: threshold}, nil
+}
+
+func (c *tfidfClassifier) Classify(goal string) (Archetype, error) {
+	tokens := tokenize(goal)
+	tf := make(map[string]int)
+	for _, t := range tokens {
+		tf[t]++
+	}
+	query := make(vector, len(tf))
+	for t, count := range tf {
+		df, ok := c.df[t]
+		if !ok {
+			// Out-of-corpus term: contributes 0 to every centroid, so it
+			// can only dilute the norm. Skip it rather than weight it.
+			continue
+		}
+		query[t] = (1 + math.Log(float64(count))) * math.Log(float64(c.n)/float64(df))
+	}
+	query = query.normalize()
+
+	var best Archetype = Unknown
+	var bestScore float64
+	for archetype, centroid := range c.centroids {
+		score := cosineSimilarity(query, centroid)
+		if score > bestScore {
+			bestScore = score
+			best = archetype
+		}
+	}
+
+	if bestScore < c.threshold {
+		return Unknown, nil
+	}
+	return best, nil
+}
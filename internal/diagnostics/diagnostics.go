@@ -0,0 +1,136 @@
+// Package diagnostics validates an assembled prompt and renders findings
+// compiler-style: the offending text with the flagged range underlined.
+package diagnostics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Severity indicates how serious a Diagnostic is.
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// Diagnostic flags a span of text within one prompt field.
+type Diagnostic struct {
+	Severity Severity
+	Message  string
+	Field    string // "Goal", "Return Format", "Warnings", or "Context Dump"
+	Start    int    // byte offset into the field's text
+	End      int    // byte offset, exclusive
+}
+
+// Fields is the assembled prompt, as handed to validators.
+type Fields struct {
+	Goal         string
+	ReturnFormat string
+	Warnings     string
+	ContextDump  string
+}
+
+func (f Fields) text(field string) string {
+	switch field {
+	case "Goal":
+		return f.Goal
+	case "Return Format":
+		return f.ReturnFormat
+	case "Warnings":
+		return f.Warnings
+	case "Context Dump":
+		return f.ContextDump
+	default:
+		return ""
+	}
+}
+
+// Validator inspects Fields and returns any Diagnostics it finds.
+type Validator func(Fields) []Diagnostic
+
+// Run applies every validator to fields and returns the combined
+// diagnostics.
+func Run(fields Fields, validators ...Validator) []Diagnostic {
+	var all []Diagnostic
+	for _, v := range validators {
+		all = append(all, v(fields)...)
+	}
+	return all
+}
+
+// HasErrors reports whether any diagnostic is Error severity.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+var severityColor = map[Severity]lipgloss.Color{
+	Info:    lipgloss.Color("12"),
+	Warning: lipgloss.Color("11"),
+	Error:   lipgloss.Color("9"),
+}
+
+// Render formats diags as compiler-style snippets: the line containing
+// the flagged span, with the span underlined beneath it.
+func Render(fields Fields, diags []Diagnostic) string {
+	var sb strings.Builder
+	for _, d := range diags {
+		style := lipgloss.NewStyle().Bold(true).Foreground(severityColor[d.Severity])
+		fmt.Fprintf(&sb, "%s: %s (%s)\n", style.Render(d.Severity.String()), d.Message, d.Field)
+
+		line, col := lineAndColumn(fields.text(d.Field), d.Start, d.End)
+		if line != "" {
+			sb.WriteString("  " + line + "\n")
+			sb.WriteString("  " + strings.Repeat(" ", col.start) + lipgloss.NewStyle().Foreground(severityColor[d.Severity]).Render(strings.Repeat("^", max1(col.end-col.start))) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+type columns struct{ start, end int }
+
+// lineAndColumn returns the single line containing byte offset start
+// within text, plus the start/end column of the [start,end) span relative
+// to that line.
+func lineAndColumn(text string, start, end int) (string, columns) {
+	if start < 0 || start > len(text) {
+		return "", columns{}
+	}
+	lineStart := strings.LastIndexByte(text[:start], '\n') + 1
+	lineEndRel := strings.IndexByte(text[start:], '\n')
+	lineEnd := len(text)
+	if lineEndRel >= 0 {
+		lineEnd = start + lineEndRel
+	}
+	if end > lineEnd {
+		end = lineEnd
+	}
+	return text[lineStart:lineEnd], columns{start: start - lineStart, end: end - lineStart}
+}
+
+func max1(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
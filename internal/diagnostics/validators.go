@@ -0,0 +1,120 @@
+package diagnostics
+
+import (
+	"strings"
+)
+
+// MaxContextDumpSize is the byte threshold above which ContextDumpSize
+// flags the context dump as excessive.
+const MaxContextDumpSize = 4000
+
+// conflictPairs are phrase pairs that contradict each other when both
+// appear in Warnings.
+var conflictPairs = [][2]string{
+	{"be concise", "exhaustive detail"},
+	{"be brief", "exhaustive detail"},
+	{"short answer", "exhaustive detail"},
+	{"no explanation", "explain in detail"},
+}
+
+// ambiguousPronouns are pronouns that read as ambiguous when a goal has
+// no preceding noun for them to refer to.
+var ambiguousPronouns = []string{"it", "this", "that", "they", "them"}
+
+// KnownPlaceholders maps each field name to the exact placeholder text
+// shown in its huh input. main.go's initial form reads its placeholders
+// from this map, so it is the single source of truth for the wording, and
+// PlaceholderText can
+// detect an unedited field without matching on legitimate text that
+// merely contains "e.g.,".
+var KnownPlaceholders = map[string]string{
+	"Goal":          "e.g., Summarize the key requirements",
+	"Return Format": "e.g., Bulleted list",
+	"Warnings":      "e.g., Exclude information about XYZ",
+	"Context Dump":  "e.g., Paste relevant sections of compliance docs here",
+}
+
+// PlaceholderText flags a field left equal to its unedited placeholder
+// text.
+func PlaceholderText(f Fields) []Diagnostic {
+	var diags []Diagnostic
+	fields := map[string]string{
+		"Goal": f.Goal, "Return Format": f.ReturnFormat,
+		"Warnings": f.Warnings, "Context Dump": f.ContextDump,
+	}
+	for name, text := range fields {
+		if strings.TrimSpace(text) == KnownPlaceholders[name] {
+			diags = append(diags, Diagnostic{
+				Severity: Error,
+				Message:  "placeholder text was left unedited",
+				Field:    name,
+				Start:    0,
+				End:      len(text),
+			})
+		}
+	}
+	return diags
+}
+
+// ContextDumpSize flags a context dump larger than MaxContextDumpSize
+// bytes, which tends to blow past a model's useful context window.
+func ContextDumpSize(f Fields) []Diagnostic {
+	if len(f.ContextDump) <= MaxContextDumpSize {
+		return nil
+	}
+	return []Diagnostic{{
+		Severity: Warning,
+		Message:  "context dump is unusually large and may dilute the prompt",
+		Field:    "Context Dump",
+		Start:    0,
+		End:      len(f.ContextDump),
+	}}
+}
+
+// ConflictingWarnings flags pairs of warnings that contradict each other,
+// such as asking for both brevity and exhaustive detail.
+func ConflictingWarnings(f Fields) []Diagnostic {
+	lower := strings.ToLower(f.Warnings)
+	var diags []Diagnostic
+	for _, pair := range conflictPairs {
+		ai, bi := strings.Index(lower, pair[0]), strings.Index(lower, pair[1])
+		if ai < 0 || bi < 0 {
+			continue
+		}
+		start, end := ai, ai+len(pair[0])
+		if bi < ai {
+			start, end = bi, bi+len(pair[1])
+		}
+		diags = append(diags, Diagnostic{
+			Severity: Error,
+			Message:  "warnings \"" + pair[0] + "\" and \"" + pair[1] + "\" conflict",
+			Field:    "Warnings",
+			Start:    start,
+			End:      end,
+		})
+	}
+	return diags
+}
+
+// AmbiguousPronouns flags a goal that opens with a pronoun with nothing
+// preceding it to refer to.
+func AmbiguousPronouns(f Fields) []Diagnostic {
+	trimmed := strings.TrimSpace(f.Goal)
+	lower := strings.ToLower(trimmed)
+	for _, pronoun := range ambiguousPronouns {
+		if lower == pronoun || strings.HasPrefix(lower, pronoun+" ") {
+			return []Diagnostic{{
+				Severity: Warning,
+				Message:  "goal opens with an ambiguous pronoun with nothing for it to refer to",
+				Field:    "Goal",
+				Start:    0,
+				End:      len(pronoun),
+			}}
+		}
+	}
+	return nil
+}
+
+// Default is the validator set main.go runs after enrichment and before
+// confirmation.
+var Default = []Validator{PlaceholderText, ContextDumpSize, ConflictingWarnings, AmbiguousPronouns}
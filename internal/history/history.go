@@ -0,0 +1,155 @@
+// Package history persists generated prompts to a local JSONL file so
+// users can revisit or fork past sessions instead of retyping them.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultLimit is the number of entries kept by Prune when no explicit
+// limit is given.
+const DefaultLimit = 200
+
+// Entry is a single generated prompt, as it existed at the time it was
+// generated.
+type Entry struct {
+	Goal         string    `json:"goal"`
+	ReturnFormat string    `json:"return_format"`
+	Warnings     string    `json:"warnings"`
+	ContextDump  string    `json:"context_dump"`
+	Archetype    string    `json:"archetype"`
+	Provider     string    `json:"provider,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Title returns a short, single-line label for display in a picker.
+func (e Entry) Title() string {
+	goal := e.Goal
+	if len(goal) > 60 {
+		goal = goal[:57] + "..."
+	}
+	return fmt.Sprintf("%s — %s", e.CreatedAt.Format("2006-01-02 15:04"), goal)
+}
+
+// Store reads and appends Entries to a JSONL file on disk.
+type Store struct {
+	path string
+}
+
+// DefaultPath returns ~/.prompttogo/history.jsonl.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("history: resolve home dir: %w", err)
+	}
+	return filepath.Join(home, ".prompttogo", "history.jsonl"), nil
+}
+
+// NewStore returns a Store backed by the file at path, creating its parent
+// directory if necessary.
+func NewStore(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("history: create history dir: %w", err)
+	}
+	return &Store{path: path}, nil
+}
+
+// Append writes entry to the end of the history file.
+func (s *Store) Append(entry Entry) error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("history: open history file: %w", err)
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("history: encode entry: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("history: write entry: %w", err)
+	}
+	return nil
+}
+
+// Load reads all entries from the history file, oldest first. A missing
+// file is treated as an empty history, not an error.
+func (s *Store) Load() ([]Entry, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("history: open history file: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("history: decode entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("history: read history file: %w", err)
+	}
+	return entries, nil
+}
+
+// Prune keeps only the most recent limit entries in the history file. A
+// limit of 0 uses DefaultLimit.
+func (s *Store) Prune(limit int) error {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	entries, err := s.Load()
+	if err != nil {
+		return err
+	}
+	if len(entries) <= limit {
+		return nil
+	}
+	entries = entries[len(entries)-limit:]
+
+	f, err := os.Create(s.path)
+	if err != nil {
+		return fmt.Errorf("history: rewrite history file: %w", err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("history: encode entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// Export writes all entries to w as a single JSON array.
+func (s *Store) Export(w *os.File) error {
+	entries, err := s.Load()
+	if err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = []Entry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
@@ -0,0 +1,58 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// fencedCodeBlock matches a ```lang\n...\n``` fence, capturing the
+// (optional) language and the code between the fences.
+var fencedCodeBlock = regexp.MustCompile("(?s)```([a-zA-Z0-9_+-]*)\\n(.*?)```")
+
+// highlightCodeBlocks finds fenced code blocks inside text and replaces
+// each with a syntax-highlighted terminal rendering, leaving everything
+// else untouched.
+func highlightCodeBlocks(text string) string {
+	return fencedCodeBlock.ReplaceAllStringFunc(text, func(block string) string {
+		match := fencedCodeBlock.FindStringSubmatch(block)
+		lang, code := match[1], match[2]
+
+		highlighted, err := highlight(lang, code)
+		if err != nil {
+			return block
+		}
+		return "```" + lang + "\n" + highlighted + "```"
+	})
+}
+
+// highlight renders code in lang (or a best guess, if lang is empty) as
+// ANSI-colored text for the terminal.
+func highlight(lang, code string) (string, error) {
+	lexer := lexers.Get(lang)
+	if lexer == nil {
+		lexer = lexers.Analyse(code)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if err := formatters.TTY256.Format(&sb, styles.Get("monokai"), iterator); err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(sb.String(), "\n") {
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
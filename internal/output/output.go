@@ -0,0 +1,123 @@
+// Package output renders an assembled prompt in one of several formats:
+// the existing plain terminal block, Markdown, JSON, or a ChatML message
+// list ready to paste into an OpenAI-style API call.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Format selects which Formatter to use.
+type Format string
+
+const (
+	Text     Format = "text"
+	Markdown Format = "markdown"
+	JSON     Format = "json"
+	ChatML   Format = "chatml"
+)
+
+// All lists every supported format, in the order they should be offered
+// to a user.
+var All = []Format{Text, Markdown, JSON, ChatML}
+
+// Prompt is the assembled prompt to render.
+type Prompt struct {
+	Goal         string `json:"goal"`
+	ReturnFormat string `json:"return_format"`
+	Warnings     string `json:"warnings"`
+	ContextDump  string `json:"context_dump"`
+	Archetype    string `json:"archetype"`
+}
+
+// Formatter renders a Prompt as a string in its own format.
+type Formatter interface {
+	Format(Prompt) (string, error)
+}
+
+// NewFormatter returns the Formatter for f, or an error if f is unknown.
+func NewFormatter(f Format) (Formatter, error) {
+	switch f {
+	case Text, "":
+		return textFormatter{}, nil
+	case Markdown:
+		return markdownFormatter{}, nil
+	case JSON:
+		return jsonFormatter{}, nil
+	case ChatML:
+		return chatMLFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("output: unknown format %q", f)
+	}
+}
+
+type textFormatter struct{}
+
+func (textFormatter) Format(p Prompt) (string, error) {
+	labelStyle := lipgloss.NewStyle().Bold(true)
+	contentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb,
+		"%s\n%s\n\n%s\n%s\n\n%s\n%s\n\n%s\n%s\n\n",
+		labelStyle.Render("Goal:"), contentStyle.Render(p.Goal),
+		labelStyle.Render("Return Format:"), contentStyle.Render(p.ReturnFormat),
+		labelStyle.Render("Warnings:"), contentStyle.Render(p.Warnings),
+		labelStyle.Render("Context Dump:"), highlightCodeBlocks(p.ContextDump),
+	)
+	return sb.String(), nil
+}
+
+type markdownFormatter struct{}
+
+func (markdownFormatter) Format(p Prompt) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Goal\n\n%s\n\n", p.Goal)
+	fmt.Fprintf(&sb, "## Return Format\n\n%s\n\n", p.ReturnFormat)
+	fmt.Fprintf(&sb, "## Warnings\n\n%s\n\n", p.Warnings)
+	fmt.Fprintf(&sb, "## Context Dump\n\n%s\n", p.ContextDump)
+	return sb.String(), nil
+}
+
+type jsonFormatter struct{}
+
+func (jsonFormatter) Format(p Prompt) (string, error) {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("output: encode json: %w", err)
+	}
+	return string(data), nil
+}
+
+// chatMLMessage is a single role-tagged message, OpenAI chat API style.
+type chatMLMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatMLFormatter struct{}
+
+func (chatMLFormatter) Format(p Prompt) (string, error) {
+	var content strings.Builder
+	content.WriteString(p.Goal)
+	if p.ReturnFormat != "" {
+		fmt.Fprintf(&content, "\n\nReturn format:\n%s", p.ReturnFormat)
+	}
+	if p.Warnings != "" {
+		fmt.Fprintf(&content, "\n\nWarnings:\n%s", p.Warnings)
+	}
+	if p.ContextDump != "" {
+		fmt.Fprintf(&content, "\n\nContext:\n%s", p.ContextDump)
+	}
+
+	messages := []chatMLMessage{{Role: "user", Content: content.String()}}
+	data, err := json.MarshalIndent(messages, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("output: encode chatml: %w", err)
+	}
+	return string(data), nil
+}
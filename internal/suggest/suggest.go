@@ -0,0 +1,68 @@
+// Package suggest offers "did you mean?" corrections for user input that
+// almost, but doesn't quite, match a known set of candidates.
+package suggest
+
+// Distance computes the Levenshtein edit distance between a and b: the
+// minimum number of single-character inserts, deletes, or substitutions
+// needed to turn a into b.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows, cols := len(ra)+1, len(rb)+1
+
+	dp := make([][]int, rows)
+	for i := range dp {
+		dp[i] = make([]int, cols)
+		dp[i][0] = i
+	}
+	for j := 0; j < cols; j++ {
+		dp[0][j] = j
+	}
+
+	for i := 1; i < rows; i++ {
+		for j := 1; j < cols; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			dp[i][j] = min3(
+				dp[i-1][j]+1,      // delete
+				dp[i][j-1]+1,      // insert
+				dp[i-1][j-1]+cost, // substitute
+			)
+		}
+	}
+
+	return dp[rows-1][cols-1]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+// ClosestMatch returns the candidate closest to input by edit distance,
+// along with true, provided that distance is within maxDist. If no
+// candidate is within maxDist, it returns "", false.
+func ClosestMatch(input string, candidates []string, maxDist int) (string, bool) {
+	best := ""
+	bestDist := maxDist + 1
+
+	for _, candidate := range candidates {
+		d := Distance(input, candidate)
+		if d < bestDist {
+			bestDist = d
+			best = candidate
+		}
+	}
+
+	if bestDist > maxDist {
+		return "", false
+	}
+	return best, true
+}
@@ -0,0 +1,181 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Store resolves templates from the directories prompttogo knows about:
+// the curated set shipped alongside the binary, and the user's own
+// installed/added templates.
+type Store struct {
+	bundledDir string
+	userDir    string
+}
+
+// DefaultUserDir returns ~/.prompttogo/templates, creating it if needed.
+func DefaultUserDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("templates: resolve home dir: %w", err)
+	}
+	dir := filepath.Join(home, ".prompttogo", "templates")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("templates: create templates dir: %w", err)
+	}
+	return dir, nil
+}
+
+// NewStore returns a Store that looks for curated templates in
+// bundledDir (typically "templates" next to the repo/binary) and
+// user-installed ones in userDir.
+func NewStore(bundledDir, userDir string) *Store {
+	return &Store{bundledDir: bundledDir, userDir: userDir}
+}
+
+// List returns every known template, curated ones first.
+func (s *Store) List() ([]*Template, error) {
+	bundled, err := LoadDir(s.bundledDir)
+	if err != nil {
+		return nil, err
+	}
+	installed, err := LoadDir(s.userDir)
+	if err != nil {
+		return nil, err
+	}
+	return append(bundled, installed...), nil
+}
+
+// Get returns the named template, searching user-installed templates
+// before curated ones.
+func (s *Store) Get(name string) (*Template, error) {
+	all, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].Name == name {
+			return all[i], nil
+		}
+	}
+	return nil, fmt.Errorf("templates: no template named %q", name)
+}
+
+// Add validates the template at path and copies it into the user's
+// template directory. It refuses to overwrite an existing template with
+// the same name unless force is true.
+func (s *Store) Add(path string, force bool) error {
+	t, err := Load(path)
+	if err != nil {
+		return err
+	}
+	return s.writeToUserDir(t, force, nil)
+}
+
+// Install fetches templates from a git URL or local path and copies any
+// *.yaml/*.yml files found into the user's template directory. A source
+// is treated as a git URL when it starts with a URL scheme or ends in
+// ".git"; anything else is treated as a local file or directory. It
+// refuses to overwrite an existing template with the same name unless
+// force is true.
+func (s *Store) Install(source string, force bool) error {
+	if looksLikeGitURL(source) {
+		dir, err := os.MkdirTemp("", "prompttogo-template-*")
+		if err != nil {
+			return fmt.Errorf("templates: create temp dir: %w", err)
+		}
+		defer os.RemoveAll(dir)
+
+		cmd := exec.Command("git", "clone", "--depth", "1", source, dir)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("templates: git clone %s: %w\n%s", source, err, out)
+		}
+		return s.installFromDir(dir, force)
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return fmt.Errorf("templates: stat %s: %w", source, err)
+	}
+	if info.IsDir() {
+		return s.installFromDir(source, force)
+	}
+	return s.Add(source, force)
+}
+
+func (s *Store) installFromDir(dir string, force bool) error {
+	found, err := LoadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(found) == 0 {
+		return fmt.Errorf("templates: no *.yaml templates found in %s", dir)
+	}
+	// Loaded once and reused across the loop below instead of per-file,
+	// since it doesn't change as we write into userDir.
+	var bundled []*Template
+	if !force {
+		bundled, _ = LoadDir(s.bundledDir)
+	}
+	for _, t := range found {
+		if err := s.writeToUserDir(t, force, bundled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeToUserDir copies t into the user's template directory, refusing
+// to clobber a differently-sourced template already installed under the
+// same name, or one that collides with a curated template in bundled,
+// unless force is true. bundled may be nil, in which case it is loaded
+// on demand; callers writing several templates in a loop should load it
+// once and pass it in.
+func (s *Store) writeToUserDir(t *Template, force bool, bundled []*Template) error {
+	if err := os.MkdirAll(s.userDir, 0o755); err != nil {
+		return fmt.Errorf("templates: create templates dir: %w", err)
+	}
+	src := t.path
+	if src == "" {
+		return fmt.Errorf("templates: template %q has no source file", t.Name)
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("templates: read %s: %w", src, err)
+	}
+	dest := filepath.Join(s.userDir, t.Name+filepath.Ext(src))
+	if rel, err := filepath.Rel(s.userDir, dest); err != nil || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("templates: %q resolves outside the templates dir", t.Name)
+	}
+	if !force {
+		if existing, err := os.ReadFile(dest); err == nil && string(existing) != string(data) {
+			return fmt.Errorf("templates: %q is already installed with different contents; rerun with --force to overwrite", t.Name)
+		} else if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("templates: read %s: %w", dest, err)
+		}
+		if bundled == nil {
+			bundled, _ = LoadDir(s.bundledDir)
+		}
+		for _, b := range bundled {
+			if b.Name == t.Name {
+				return fmt.Errorf("templates: %q collides with a bundled template; rerun with --force to shadow it", t.Name)
+			}
+		}
+	}
+	if err := os.WriteFile(dest, data, 0o644); err != nil {
+		return fmt.Errorf("templates: write %s: %w", dest, err)
+	}
+	return nil
+}
+
+func looksLikeGitURL(source string) bool {
+	for _, prefix := range []string{"http://", "https://", "git@", "ssh://"} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return strings.HasSuffix(source, ".git")
+}
@@ -0,0 +1,187 @@
+// Package templates implements prompttogo's shareable YAML prompt
+// templates: curated starting points for common tasks that a user fills
+// in with a few variables instead of writing a goal from scratch.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	textTemplate "text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// validNamePattern restricts Template.Name to characters safe for use as
+// a filename component, since store.go derives on-disk paths from it.
+var validNamePattern = regexp.MustCompile(`^[A-Za-z0-9 _-]+$`)
+
+// Variable is a single fill-in-the-blank value a template's goal_template
+// references via {{.Name}}.
+type Variable struct {
+	Name       string `yaml:"name"`
+	Type       string `yaml:"type"` // "string", "int", or "bool"; "string" if empty
+	Default    string `yaml:"default"`
+	Validation string `yaml:"validation"` // optional regexp the value must match
+}
+
+// Validate reports whether value satisfies v's declared Type and
+// Validation regexp.
+func (v Variable) Validate(value string) error {
+	switch v.Type {
+	case "", "string":
+		// No coercion.
+	case "int":
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%q must be an integer, got %q", v.Name, value)
+		}
+	case "bool":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q must be a boolean, got %q", v.Name, value)
+		}
+	default:
+		return fmt.Errorf("%q has unknown type %q", v.Name, v.Type)
+	}
+	if v.Validation != "" {
+		re, err := regexp.Compile(v.Validation)
+		if err != nil {
+			return fmt.Errorf("%q has an invalid validation regexp %q: %w", v.Name, v.Validation, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("%q value %q does not match pattern %q", v.Name, value, v.Validation)
+		}
+	}
+	return nil
+}
+
+// Template is a shareable prompt starting point.
+type Template struct {
+	Name         string     `yaml:"name"`
+	Description  string     `yaml:"description"`
+	Archetype    string     `yaml:"archetype"`
+	GoalTemplate string     `yaml:"goal_template"`
+	ReturnFormat string     `yaml:"return_format"`
+	Warnings     []string   `yaml:"warnings"`
+	Variables    []Variable `yaml:"variables"`
+
+	// path is where the template was loaded from, set by Load/LoadDir.
+	path string
+}
+
+// Parse decodes a single template from YAML bytes.
+func Parse(data []byte) (*Template, error) {
+	var t Template
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("templates: parse: %w", err)
+	}
+	if t.Name == "" {
+		return nil, fmt.Errorf("templates: template is missing a name")
+	}
+	if !validNamePattern.MatchString(t.Name) {
+		return nil, fmt.Errorf("templates: template name %q must match %s", t.Name, validNamePattern)
+	}
+	for _, v := range t.Variables {
+		switch v.Type {
+		case "", "string", "int", "bool":
+		default:
+			return nil, fmt.Errorf("templates: variable %q has unknown type %q", v.Name, v.Type)
+		}
+		if v.Validation != "" {
+			if _, err := regexp.Compile(v.Validation); err != nil {
+				return nil, fmt.Errorf("templates: variable %q has invalid validation regexp: %w", v.Name, err)
+			}
+		}
+		if v.Default != "" {
+			if err := v.Validate(v.Default); err != nil {
+				return nil, fmt.Errorf("templates: variable %q default fails its own validation: %w", v.Name, err)
+			}
+		}
+	}
+	return &t, nil
+}
+
+// Load reads and parses a single template file.
+func Load(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("templates: read %s: %w", path, err)
+	}
+	t, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	t.path = path
+	return t, nil
+}
+
+// LoadDir loads every *.yaml/*.yml file directly under dir. A missing
+// directory yields an empty slice, not an error.
+func LoadDir(dir string) ([]*Template, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("templates: read dir %s: %w", dir, err)
+	}
+
+	var loaded []*Template
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		t, err := Load(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, t)
+	}
+	return loaded, nil
+}
+
+// Render validates vars against each declared Variable's Type and
+// Validation, falling back to the variable's Default for any name vars
+// omits, then fills the goal_template with the resolved values using
+// text/template.
+func (t *Template) Render(vars map[string]string) (string, error) {
+	resolved := make(map[string]string, len(t.Variables))
+	for _, v := range t.Variables {
+		value, ok := vars[v.Name]
+		if !ok {
+			value = v.Default
+		}
+		// An omitted variable with no default is treated as optional and
+		// left unvalidated; anything explicitly supplied, or defaulted
+		// to a non-empty value, must satisfy Type/Validation.
+		if ok || v.Default != "" {
+			if err := v.Validate(value); err != nil {
+				return "", fmt.Errorf("templates: %w", err)
+			}
+		}
+		resolved[v.Name] = value
+	}
+
+	tmpl, err := textTemplate.New(t.Name).Parse(t.GoalTemplate)
+	if err != nil {
+		return "", fmt.Errorf("templates: parse goal_template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, resolved); err != nil {
+		return "", fmt.Errorf("templates: render goal_template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// WarningsText joins Warnings into the newline-separated form the rest of
+// prompttogo expects.
+func (t *Template) WarningsText() string {
+	return strings.Join(t.Warnings, "\n")
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
@@ -9,13 +11,14 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/huh/spinner"
 	"github.com/charmbracelet/lipgloss"
-)
 
-// Constants for archetypes
-const (
-	ArchetypeSummarization = "Summarization"
-	ArchetypeQA            = "QuestionAnswering"
-	ArchetypeUnknown       = "Unknown"
+	"github.com/MasonBrott/prompttogo/internal/archetype"
+	"github.com/MasonBrott/prompttogo/internal/diagnostics"
+	"github.com/MasonBrott/prompttogo/internal/history"
+	"github.com/MasonBrott/prompttogo/internal/output"
+	"github.com/MasonBrott/prompttogo/internal/suggest"
+	"github.com/MasonBrott/prompttogo/internal/templates"
+	"github.com/MasonBrott/prompttogo/providers"
 )
 
 var (
@@ -25,55 +28,67 @@ var (
 	contextDump      string
 	selectedWarnings []string // Warnings selected from suggestions
 	confirm          bool
-)
 
-// detectArchetype analyzes the goal to determine the likely user intent.
-func detectArchetype(goal string) string {
-	lowerGoal := strings.ToLower(goal)
+	selectedProvider string
+	selectedModel    string
 
-	// Keywords for Question Answering (prioritized)
-	qaKeywords := []string{"what is", "explain", "how does", "list", "compare", "does it", "can i", "where", "who", "when", "why"}
-	for _, keyword := range qaKeywords {
-		if strings.Contains(lowerGoal, keyword) {
-			return ArchetypeQA
-		}
-	}
+	startChoice string
+	historyPick string
 
-	// Keywords for Summarization
-	summarizeKeywords := []string{"summarize", "summary", "overview", "tldr", "key points", "abstract", "give me the gist"}
-	for _, keyword := range summarizeKeywords {
-		if strings.Contains(lowerGoal, keyword) {
-			return ArchetypeSummarization
-		}
-	}
+	finalArchetype archetype.Archetype
 
-	return ArchetypeUnknown
-}
+	archetypeOverride string
+)
 
 // getGuidance returns helpful tips based on the detected archetype.
-func getGuidance(archetype string) []string {
-	switch archetype {
-	case ArchetypeSummarization:
+func getGuidance(a archetype.Archetype) []string {
+	switch a {
+	case archetype.Summarization:
 		return []string{
 			"Tip: Consider specifying desired length (e.g., 'one paragraph', 'bullet points').",
 			"Tip: Mention the target audience if applicable.",
 			"Tip: Focus on specific aspects if needed (e.g., 'summarize security controls').",
 		}
-	case ArchetypeQA:
+	case archetype.QA:
 		return []string{
 			"Tip: Ensure your question is specific for better answers.",
 			"Tip: Use terminology likely found in the provided context.",
 			"Tip: If asking about multiple things, consider separate prompts.",
 		}
+	case archetype.Classification:
+		return []string{
+			"Tip: Enumerate every valid label up front so the model can't invent new ones.",
+			"Tip: Give an example for each label if the distinction is subtle.",
+		}
+	case archetype.Extraction:
+		return []string{
+			"Tip: Specify the exact fields you want extracted and their expected format.",
+			"Tip: State what to do when a field is missing, rather than leaving it to guess.",
+		}
+	case archetype.Rewriting:
+		return []string{
+			"Tip: Describe the target tone or audience (e.g., 'more formal', 'for a child').",
+			"Tip: Say what must stay unchanged, such as names or figures.",
+		}
+	case archetype.CodeGeneration:
+		return []string{
+			"Tip: Name the language and any libraries or style conventions to follow.",
+			"Tip: Mention whether tests or usage examples should be included.",
+		}
+	case archetype.Translation:
+		return []string{
+			"Tip: Specify both the source and target language explicitly.",
+			"Tip: Note if tone, formality, or regional dialect matters.",
+		}
 	default:
 		return nil // No guidance for unknown archetype
 	}
 }
 
 // getEnrichmentSuggestions provides refined suggestions for goal, format, and warnings.
-func getEnrichmentSuggestions(archetype string, originalGoal string, originalFormat string) (suggestedGoal string, suggestedFormats []huh.Option[string], suggestedWarnings []huh.Option[string]) {
-	switch archetype {
-	case ArchetypeSummarization:
+func getEnrichmentSuggestions(a archetype.Archetype, originalGoal string, originalFormat string) (suggestedGoal string, suggestedFormats []huh.Option[string], suggestedWarnings []huh.Option[string]) {
+	switch a {
+	case archetype.Summarization:
 		suggestedGoal = "Summarize the key requirements and obligations mentioned in the provided documents."
 		suggestedFormats = []huh.Option[string]{
 			huh.NewOption("Bulleted list of key points", "Bulleted list of key points"),
@@ -83,23 +98,9 @@ func getEnrichmentSuggestions(archetype string, originalGoal string, originalFor
 			huh.NewOption("Focus only on actionable requirements", "Focus on requirements"),
 			huh.NewOption("Avoid technical jargon where possible", "Avoid jargon"),
 		}
-		// Add original format as an option if it's different and not empty
-		isOriginalAlreadySuggested := false
-		for _, opt := range suggestedFormats {
-			if opt.Value == originalFormat {
-				isOriginalAlreadySuggested = true
-				break
-			}
-		}
-		if !isOriginalAlreadySuggested {
-			if originalFormat != "" {
-				suggestedFormats = append(suggestedFormats, huh.NewOption("Keep: "+originalFormat, originalFormat))
-			} else {
-				suggestedFormats = append(suggestedFormats, huh.NewOption("Keep original (empty)", ""))
-			}
-		}
+		suggestedFormats = addOriginalFormatOption(suggestedFormats, originalFormat)
 
-	case ArchetypeQA:
+	case archetype.QA:
 		// Prepend standard framing to the user's original question/goal
 		suggestedGoal = fmt.Sprintf("Based *only* on the provided documents, answer the question: %s", originalGoal)
 		suggestedFormats = []huh.Option[string]{
@@ -112,21 +113,67 @@ func getEnrichmentSuggestions(archetype string, originalGoal string, originalFor
 			huh.NewOption("Cite the source section(s) for the answer", "Cite sources"),
 			huh.NewOption("If the answer is not found, state that clearly", "State if not found"),
 		}
-		// Add original format as an option if it's different and not empty
-		isOriginalAlreadySuggested := false
-		for _, opt := range suggestedFormats {
-			if opt.Value == originalFormat {
-				isOriginalAlreadySuggested = true
-				break
-			}
+		suggestedFormats = addOriginalFormatOption(suggestedFormats, originalFormat)
+
+	case archetype.Classification:
+		suggestedGoal = fmt.Sprintf("Classify the following using the allowed labels: %s", originalGoal)
+		suggestedFormats = []huh.Option[string]{
+			huh.NewOption("Single label only", "Single label only"),
+			huh.NewOption("Label with a one-sentence justification", "Label with justification"),
 		}
-		if !isOriginalAlreadySuggested {
-			if originalFormat != "" {
-				suggestedFormats = append(suggestedFormats, huh.NewOption("Keep: "+originalFormat, originalFormat))
-			} else {
-				suggestedFormats = append(suggestedFormats, huh.NewOption("Keep original (empty)", ""))
-			}
+		suggestedWarnings = []huh.Option[string]{
+			huh.NewOption("Only use labels from the provided set", "Restrict to provided labels"),
+			huh.NewOption("If uncertain, say so instead of guessing", "Flag uncertainty"),
+		}
+		suggestedFormats = addOriginalFormatOption(suggestedFormats, originalFormat)
+
+	case archetype.Extraction:
+		suggestedGoal = fmt.Sprintf("Extract the following from the provided text: %s", originalGoal)
+		suggestedFormats = []huh.Option[string]{
+			huh.NewOption("Structured JSON object", "Structured JSON object"),
+			huh.NewOption("Table with one row per match", "Table with one row per match"),
+		}
+		suggestedWarnings = []huh.Option[string]{
+			huh.NewOption("Leave a field blank if it isn't present", "Leave missing fields blank"),
+			huh.NewOption("Do not fabricate values not present in the text", "Do not fabricate values"),
+		}
+		suggestedFormats = addOriginalFormatOption(suggestedFormats, originalFormat)
+
+	case archetype.Rewriting:
+		suggestedGoal = fmt.Sprintf("Rewrite the following: %s", originalGoal)
+		suggestedFormats = []huh.Option[string]{
+			huh.NewOption("Same length as the original", "Same length as the original"),
+			huh.NewOption("As concise as possible", "As concise as possible"),
+		}
+		suggestedWarnings = []huh.Option[string]{
+			huh.NewOption("Preserve all facts and figures exactly", "Preserve facts and figures"),
+			huh.NewOption("Keep the original meaning intact", "Keep meaning intact"),
+		}
+		suggestedFormats = addOriginalFormatOption(suggestedFormats, originalFormat)
+
+	case archetype.CodeGeneration:
+		suggestedGoal = fmt.Sprintf("Write code to: %s", originalGoal)
+		suggestedFormats = []huh.Option[string]{
+			huh.NewOption("Code only, no explanation", "Code only, no explanation"),
+			huh.NewOption("Code with a short explanation", "Code with a short explanation"),
+		}
+		suggestedWarnings = []huh.Option[string]{
+			huh.NewOption("Include error handling", "Include error handling"),
+			huh.NewOption("Match the existing code style", "Match existing code style"),
+		}
+		suggestedFormats = addOriginalFormatOption(suggestedFormats, originalFormat)
+
+	case archetype.Translation:
+		suggestedGoal = fmt.Sprintf("Translate the following: %s", originalGoal)
+		suggestedFormats = []huh.Option[string]{
+			huh.NewOption("Translation only", "Translation only"),
+			huh.NewOption("Translation with notes on idioms", "Translation with notes on idioms"),
+		}
+		suggestedWarnings = []huh.Option[string]{
+			huh.NewOption("Preserve the original tone and formality", "Preserve tone and formality"),
+			huh.NewOption("Keep names and technical terms untranslated", "Keep names untranslated"),
 		}
+		suggestedFormats = addOriginalFormatOption(suggestedFormats, originalFormat)
 
 	default:
 		// No suggestions for unknown archetype
@@ -151,7 +198,115 @@ func getEnrichmentSuggestions(archetype string, originalGoal string, originalFor
 	return suggestedGoal, suggestedFormats, suggestedWarnings
 }
 
+// addOriginalFormatOption appends originalFormat to formats as a "Keep: "
+// option, unless a suggestion already matches it.
+func addOriginalFormatOption(formats []huh.Option[string], originalFormat string) []huh.Option[string] {
+	for _, opt := range formats {
+		if opt.Value == originalFormat {
+			return formats
+		}
+	}
+	if originalFormat != "" {
+		return append(formats, huh.NewOption("Keep: "+originalFormat, originalFormat))
+	}
+	return append(formats, huh.NewOption("Keep original (empty)", ""))
+}
+
+// combineWarnings merges the user's freeform warnings with any suggested
+// warnings they selected during enrichment.
+func combineWarnings() string {
+	finalWarnings := warnings
+	if len(selectedWarnings) > 0 {
+		if finalWarnings != "" {
+			finalWarnings += "\n"
+		}
+		for _, sw := range selectedWarnings {
+			finalWarnings += "- " + sw + "\n"
+		}
+		finalWarnings = strings.TrimSpace(finalWarnings)
+	}
+	return finalWarnings
+}
+
+// renderOutput formats p and writes it either to outputPath or stdout.
+// formatFlag is the --format value; if empty, the user is prompted for
+// one via huh.
+func renderOutput(p output.Prompt, formatFlag, outputPath string) {
+	selectedFormat := formatFlag
+	if selectedFormat == "" {
+		selectedFormat = string(output.Text)
+		options := make([]huh.Option[string], len(output.All))
+		for i, f := range output.All {
+			options[i] = huh.NewOption(string(f), string(f))
+		}
+		formatForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title("Output format").
+					Options(options...).
+					Value(&selectedFormat),
+			),
+		)
+		if err := formatForm.Run(); err != nil {
+			selectedFormat = string(output.Text)
+		}
+	}
+
+	formatter, err := output.NewFormatter(output.Format(selectedFormat))
+	if err != nil {
+		fmt.Println("Uh oh:", err)
+		return
+	}
+	rendered, err := formatter.Format(p)
+	if err != nil {
+		fmt.Println("Uh oh:", err)
+		return
+	}
+
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, []byte(rendered), 0o644); err != nil {
+			fmt.Println("Uh oh:", err)
+			return
+		}
+		fmt.Println(lipgloss.NewStyle().Faint(true).Render("\nWrote output to " + outputPath))
+		return
+	}
+
+	fmt.Println(lipgloss.NewStyle().Render(rendered))
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		runExportCommand()
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "template" {
+		runTemplateCommand(os.Args[2:])
+		return
+	}
+
+	dryRun := flag.Bool("dry-run", false, "assemble the prompt without sending it to a provider")
+	format := flag.String("format", "", "output format: text, markdown, json, or chatml (prompts interactively if unset)")
+	outputPath := flag.String("output", "", "write the rendered prompt to this path instead of stdout")
+	flag.Parse()
+
+	classifier := newClassifier()
+
+	historyPath, err := history.DefaultPath()
+	if err != nil {
+		fmt.Println("Uh oh:", err)
+		os.Exit(1)
+	}
+	historyStore, err := history.NewStore(historyPath)
+	if err != nil {
+		fmt.Println("Uh oh:", err)
+		os.Exit(1)
+	}
+	templateStore, err := newTemplateStore()
+	if err != nil {
+		fmt.Println("Uh oh:", err)
+		os.Exit(1)
+	}
 
 	// Outer loop to allow restarting the process
 	for {
@@ -160,28 +315,30 @@ func main() {
 		selectedWarnings = []string{} // Reset selected warnings
 		confirm = false               // Reset confirmation
 
+		promptStartChoice(historyStore, templateStore)
+
 		// --- Initial Form ---
 		fmt.Println(lipgloss.NewStyle().Bold(true).Render("Step 1: Initial Prompt Details"))
 		initialForm := huh.NewForm(
 			huh.NewGroup(
 				huh.NewText().
 					Title("Goal").
-					Placeholder("e.g., Summarize the key requirements").
+					Placeholder(diagnostics.KnownPlaceholders["Goal"]).
 					CharLimit(500).
 					Value(&goal),
 				huh.NewText().
 					Title("Return Format").
-					Placeholder("e.g., Bulleted list").
+					Placeholder(diagnostics.KnownPlaceholders["Return Format"]).
 					CharLimit(500).
 					Value(&returnFormat),
 				huh.NewText().
 					Title("Warnings").
-					Placeholder("e.g., Exclude information about XYZ").
+					Placeholder(diagnostics.KnownPlaceholders["Warnings"]).
 					CharLimit(500).
 					Value(&warnings), // Captures user's manual warning input
 				huh.NewText().
 					Title("Context Dump").
-					Placeholder("e.g., Paste relevant sections of compliance docs here").
+					Placeholder(diagnostics.KnownPlaceholders["Context Dump"]).
 					CharLimit(500).
 					Value(&contextDump),
 			),
@@ -196,12 +353,43 @@ func main() {
 			fmt.Println("Uh oh:", err)
 			os.Exit(1)
 		}
+		returnFormat = suggestCorrection(returnFormat, knownFormats, "return format")
+		warnings = suggestCorrection(warnings, knownWarnings, "warning")
+
 		originalGoal := goal
 		originalFormat := returnFormat
 
 		// --- Archetype Detection and Guidance ---
-		detectedArchetype := detectArchetype(goal)
-		if detectedArchetype != ArchetypeUnknown {
+		detectedArchetype, err := classifier.Classify(goal)
+		if err != nil {
+			fmt.Println(lipgloss.NewStyle().Faint(true).Render("\nArchetype detection failed: " + err.Error()))
+			detectedArchetype = archetype.Unknown
+		}
+		if detectedArchetype == archetype.Unknown {
+			detectedArchetype = suggestArchetypeCorrection(goal)
+		}
+
+		// Let the user correct a misdetection (or opt into one) before we
+		// build enrichment suggestions around it.
+		archetypeOverride = string(detectedArchetype)
+		overrideOptions := []huh.Option[string]{huh.NewOption(string(archetype.Unknown), string(archetype.Unknown))}
+		for _, a := range archetype.All {
+			overrideOptions = append(overrideOptions, huh.NewOption(string(a), string(a)))
+		}
+		overrideForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title("Detected intent (override if wrong)").
+					Options(overrideOptions...).
+					Value(&archetypeOverride),
+			),
+		)
+		if err := overrideForm.Run(); err == nil {
+			detectedArchetype = archetype.Archetype(archetypeOverride)
+		}
+		finalArchetype = detectedArchetype
+
+		if detectedArchetype != archetype.Unknown {
 			fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("\nPrompt Guidance:"))
 			guidance := getGuidance(detectedArchetype)
 			for _, tip := range guidance {
@@ -217,7 +405,7 @@ func main() {
 
 			enrichmentForm := huh.NewForm(
 				huh.NewGroup(
-					huh.NewNote().Title("Detected Intent: "+detectedArchetype).Description("We detected a potential intent. You can refine the Goal, Return Format, and add common Warnings below."),
+					huh.NewNote().Title("Detected Intent: "+string(detectedArchetype)).Description("We detected a potential intent. You can refine the Goal, Return Format, and add common Warnings below."),
 					huh.NewText().
 						Title("Refined Goal").
 						Description("Suggested goal based on detection. Edit as needed.").
@@ -246,6 +434,35 @@ func main() {
 			fmt.Println(lipgloss.NewStyle().Faint(true).Render("\n---"))
 		}
 
+		// --- Diagnostics ---
+		promptFields := diagnostics.Fields{
+			Goal:         goal,
+			ReturnFormat: returnFormat,
+			Warnings:     combineWarnings(),
+			ContextDump:  contextDump,
+		}
+		diags := diagnostics.Run(promptFields, diagnostics.Default...)
+		if len(diags) > 0 {
+			fmt.Println(lipgloss.NewStyle().Bold(true).Render("\nDiagnostics:"))
+			fmt.Print(diagnostics.Render(promptFields, diags))
+
+			if diagnostics.HasErrors(diags) {
+				overrideDiagnostics := false
+				overrideForm := huh.NewForm(
+					huh.NewGroup(
+						huh.NewConfirm().
+							Title("Errors found above. Continue anyway?").
+							Value(&overrideDiagnostics),
+					),
+				)
+				if err := overrideForm.Run(); err != nil || !overrideDiagnostics {
+					fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("\nRestarting prompt generation so you can fix the issues above..."))
+					time.Sleep(1 * time.Second)
+					continue // back to the top of the outer loop to edit fields
+				}
+			}
+		}
+
 		// --- Confirmation Step ---
 		fmt.Println(lipgloss.NewStyle().Bold(true).Render("\nStep 3: Confirm Generation"))
 		confirmForm := huh.NewForm(
@@ -284,38 +501,494 @@ func main() {
 	_ = spinner.New().Title("Preparing your prompt...").Action(preparePrompt).Run()
 
 	{
-		var sb strings.Builder
-		labelStyle := lipgloss.NewStyle().Bold(true)
-		contentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
-
-		// Combine original warnings and selected suggested warnings
-		finalWarnings := warnings // Start with user's original input
-		if len(selectedWarnings) > 0 {
-			if finalWarnings != "" {
-				finalWarnings += "\n" // Add newline if original warnings exist
-			}
-			// Append selected warnings, prefixing each with a bullet or similar
-			for _, sw := range selectedWarnings {
-				finalWarnings += "- " + sw + "\n"
-			}
-			finalWarnings = strings.TrimSpace(finalWarnings) // Clean up trailing newline
-		}
-
-		fmt.Fprintf(&sb,
-			"%s\n%s\n\n%s\n%s\n\n%s\n%s\n\n%s\n%s\n\n",
-			labelStyle.Render("Goal:"),
-			contentStyle.Render(goal),
-			labelStyle.Render("Return Format:"),
-			contentStyle.Render(returnFormat),
-			labelStyle.Render("Warnings:"),
-			contentStyle.Render(finalWarnings), // Use the combined warnings
-			labelStyle.Render("Context Dump:"),
-			contentStyle.Render(contextDump),
+		finalWarnings := combineWarnings()
+
+		renderOutput(output.Prompt{
+			Goal:         goal,
+			ReturnFormat: returnFormat,
+			Warnings:     finalWarnings,
+			ContextDump:  contextDump,
+			Archetype:    string(finalArchetype),
+		}, *format, *outputPath)
+
+		entry := history.Entry{
+			Goal:         goal,
+			ReturnFormat: returnFormat,
+			Warnings:     finalWarnings,
+			ContextDump:  contextDump,
+			Archetype:    string(finalArchetype),
+			CreatedAt:    time.Now(),
+		}
+
+		if !*dryRun {
+			entry.Provider, entry.Model = runCompletion(finalWarnings)
+		}
+
+		if err := historyStore.Append(entry); err != nil {
+			fmt.Println(lipgloss.NewStyle().Faint(true).Render("\nCouldn't save to history: " + err.Error()))
+		}
+		if err := historyStore.Prune(history.DefaultLimit); err != nil {
+			fmt.Println(lipgloss.NewStyle().Faint(true).Render("\nCouldn't prune history: " + err.Error()))
+		}
+	}
+}
+
+// knownFormats and knownWarnings are the return-format and warning
+// phrases offered across every archetype's enrichment suggestions. A
+// freeform answer close to but not exactly one of these is likely a typo.
+var knownFormats = []string{
+	"Bulleted list of key points", "Concise paragraph overview",
+	"Direct answer", "Answer with citations to relevant sections", "Extract relevant quotes supporting the answer",
+	"Single label only", "Label with a one-sentence justification",
+	"Structured JSON object", "Table with one row per match",
+	"Same length as the original", "As concise as possible",
+	"Code only, no explanation", "Code with a short explanation",
+	"Translation only", "Translation with notes on idioms",
+}
+
+var knownWarnings = []string{
+	"Focus only on actionable requirements", "Avoid technical jargon where possible",
+	"Do not infer information not explicitly present", "Cite the source section(s) for the answer", "If the answer is not found, state that clearly",
+	"Only use labels from the provided set", "If uncertain, say so instead of guessing",
+	"Leave a field blank if it isn't present", "Do not fabricate values not present in the text",
+	"Preserve all facts and figures exactly", "Keep the original meaning intact",
+	"Include error handling", "Match the existing code style",
+	"Preserve the original tone and formality", "Keep names and technical terms untranslated",
+}
+
+// suggestCorrection compares input against candidates and, if a close but
+// inexact match exists, offers the user a confirm to accept the
+// correction. label is used in the confirm's title. Returns input
+// unchanged if there's no close match or the user declines.
+func suggestCorrection(input string, candidates []string, label string) string {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return input
+	}
+
+	match, ok := suggest.ClosestMatch(trimmed, candidates, 2)
+	if !ok || match == trimmed {
+		return input
+	}
+
+	confirmed := false
+	confirmForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Did you mean %q for %s %q?", match, label, trimmed)).
+				Value(&confirmed),
+		),
+	)
+	if err := confirmForm.Run(); err == nil && confirmed {
+		return match
+	}
+	return input
+}
+
+// suggestArchetypeCorrection looks for a token in goal within edit
+// distance 2 of a known archetype keyword and, if the user confirms,
+// returns the archetype that keyword signals. Returns Unknown if nothing
+// close is found or the user declines.
+func suggestArchetypeCorrection(goal string) archetype.Archetype {
+	keywords := archetype.Keywords()
+	candidates := make([]string, 0, len(keywords))
+	for keyword := range keywords {
+		candidates = append(candidates, keyword)
+	}
+
+	for _, token := range strings.Fields(strings.ToLower(goal)) {
+		match, ok := suggest.ClosestMatch(token, candidates, 2)
+		if !ok || match == token {
+			continue
+		}
+
+		a := keywords[match]
+		fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("11")).
+			Render(fmt.Sprintf("\nDid you mean %q (suggesting %s)?", match, a)))
+
+		confirmed := false
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title(fmt.Sprintf("Treat this as a %s prompt?", a)).
+					Value(&confirmed),
+			),
 		)
+		if err := confirmForm.Run(); err == nil && confirmed {
+			return a
+		}
+		return archetype.Unknown
+	}
+
+	return archetype.Unknown
+}
+
+// newClassifier picks an archetype.Classifier backend based on the
+// ARCHETYPE_CLASSIFIER env var ("keyword", "tfidf", or "llm"), defaulting
+// to the keyword matcher. "llm" falls back to the keyword matcher if no
+// provider is configured.
+func newClassifier() archetype.Classifier {
+	switch os.Getenv("ARCHETYPE_CLASSIFIER") {
+	case "tfidf":
+		c, err := archetype.NewTFIDF(0)
+		if err != nil {
+			fmt.Println(lipgloss.NewStyle().Faint(true).Render("\nCouldn't train TF-IDF classifier, falling back to keyword matching: " + err.Error()))
+			return archetype.NewKeyword()
+		}
+		return c
+	case "llm":
+		registry := providers.NewRegistry()
+		names := registry.Names()
+		if len(names) == 0 {
+			return archetype.NewKeyword()
+		}
+		provider, _ := registry.Get(names[0])
+		return archetype.NewLLM(provider)
+	default:
+		return archetype.NewKeyword()
+	}
+}
+
+// runCompletion lets the user pick a configured provider/model, then
+// streams the completion to the terminal. It returns the provider/model
+// that were used, both empty if completion was skipped. It is a no-op
+// (with guidance printed instead) when no provider is configured via env
+// vars.
+func runCompletion(finalWarnings string) (provider, model string) {
+	registry := providers.NewRegistry()
+	names := registry.Names()
+	if len(names) == 0 {
+		fmt.Println(lipgloss.NewStyle().Faint(true).Render("\nNo provider configured (set OPENAI_API_KEY, ANTHROPIC_API_KEY, or OLLAMA_HOST). Skipping completion; rerun with --dry-run to silence this."))
+		return "", ""
+	}
+
+	options := make([]huh.Option[string], 0, len(names))
+	for _, name := range names {
+		options = append(options, huh.NewOption(name, name))
+	}
+	selectedProvider = names[0]
+	selectedModel = ""
+
+	providerForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Provider").
+				Options(options...).
+				Value(&selectedProvider),
+			huh.NewInput().
+				Title("Model (leave blank for provider default)").
+				Value(&selectedModel),
+		),
+	)
+	if err := providerForm.Run(); err != nil {
+		if err == huh.ErrUserAborted {
+			return "", ""
+		}
+		fmt.Println("Error selecting provider:", err)
+		return "", ""
+	}
+
+	backend, ok := registry.Get(selectedProvider)
+	if !ok {
+		fmt.Println("Provider not available:", selectedProvider)
+		return "", ""
+	}
+	if selectedModel != "" {
+		switch selectedProvider {
+		case "OpenAI":
+			backend = providers.NewOpenAI(os.Getenv("OPENAI_API_KEY"), selectedModel)
+		case "Anthropic":
+			backend = providers.NewAnthropic(os.Getenv("ANTHROPIC_API_KEY"), selectedModel)
+		case "Ollama":
+			backend = providers.NewOllama(os.Getenv("OLLAMA_HOST"), selectedModel)
+		}
+	}
 
-		fmt.Println(
-			lipgloss.NewStyle().
-				Render(sb.String()),
+	prompt := providers.Prompt{
+		Goal:         goal,
+		ReturnFormat: returnFormat,
+		Warnings:     finalWarnings,
+		ContextDump:  contextDump,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var stream <-chan string
+	err := spinner.New().
+		Title(fmt.Sprintf("Sending prompt to %s...", backend.Name())).
+		Action(func() {
+			var streamErr error
+			stream, streamErr = backend.Complete(ctx, prompt)
+			if streamErr != nil {
+				fmt.Println("\nCompletion failed:", streamErr)
+			}
+		}).
+		Run()
+	if err != nil || stream == nil {
+		return "", ""
+	}
+
+	fmt.Println(lipgloss.NewStyle().Bold(true).Render("\nResponse:"))
+	tokenStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
+	for token := range stream {
+		fmt.Print(tokenStyle.Render(token))
+	}
+	fmt.Println()
+
+	return selectedProvider, selectedModel
+}
+
+// promptStartChoice offers the user a choice between starting fresh,
+// loading a past prompt for editing, or forking one as a new entry. When
+// an entry is picked, goal/returnFormat/warnings/contextDump are
+// pre-filled so the initial form below opens with its content.
+func promptStartChoice(historyStore *history.Store, templateStore *templates.Store) {
+	entries, err := historyStore.Load()
+	if err != nil {
+		fmt.Println(lipgloss.NewStyle().Faint(true).Render("\nCouldn't read history: " + err.Error()))
+		return
+	}
+	available, err := templateStore.List()
+	if err != nil {
+		fmt.Println(lipgloss.NewStyle().Faint(true).Render("\nCouldn't read templates: " + err.Error()))
+		return
+	}
+	if len(entries) == 0 && len(available) == 0 {
+		return
+	}
+
+	startChoice = "New prompt"
+	options := []huh.Option[string]{huh.NewOption("New prompt", "New prompt")}
+	if len(entries) > 0 {
+		options = append(options,
+			huh.NewOption("Load from history", "Load from history"),
+			huh.NewOption("Fork history entry", "Fork history entry"),
 		)
 	}
+	if len(available) > 0 {
+		options = append(options, huh.NewOption("Use a template", "Use a template"))
+	}
+
+	startForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Start from").
+				Options(options...).
+				Value(&startChoice),
+		),
+	)
+	if err := startForm.Run(); err != nil || startChoice == "New prompt" {
+		return
+	}
+
+	if startChoice == "Use a template" {
+		promptTemplateChoice(available)
+		return
+	}
+
+	// huh.NewSelect filters its options as the user types, which gives us
+	// the fuzzy "pick one of many" picker for free.
+	pickOptions := make([]huh.Option[string], len(entries))
+	for i, entry := range entries {
+		pickOptions[i] = huh.NewOption(entry.Title(), entry.Title())
+	}
+	historyPick = entries[len(entries)-1].Title()
+
+	pickForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Pick a history entry").
+				Options(pickOptions...).
+				Value(&historyPick),
+		),
+	)
+	if err := pickForm.Run(); err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.Title() == historyPick {
+			goal = entry.Goal
+			returnFormat = entry.ReturnFormat
+			warnings = entry.Warnings
+			contextDump = entry.ContextDump
+			break
+		}
+	}
+}
+
+// promptTemplateChoice lets the user pick one of the available templates,
+// fills in its declared variables via huh, and pre-fills goal/returnFormat
+// /warnings from the rendered result.
+func promptTemplateChoice(available []*templates.Template) {
+	options := make([]huh.Option[string], len(available))
+	for i, t := range available {
+		options[i] = huh.NewOption(fmt.Sprintf("%s — %s", t.Name, t.Description), t.Name)
+	}
+	templatePick := available[0].Name
+
+	pickForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Pick a template").
+				Options(options...).
+				Value(&templatePick),
+		),
+	)
+	if err := pickForm.Run(); err != nil {
+		return
+	}
+
+	var chosen *templates.Template
+	for _, t := range available {
+		if t.Name == templatePick {
+			chosen = t
+			break
+		}
+	}
+	if chosen == nil {
+		return
+	}
+
+	inputs := make([]string, len(chosen.Variables))
+	fields := make([]huh.Field, len(chosen.Variables))
+	for i, v := range chosen.Variables {
+		inputs[i] = v.Default
+		fields[i] = huh.NewInput().
+			Title(v.Name).
+			Placeholder(v.Default).
+			Value(&inputs[i]).
+			Validate(v.Validate)
+	}
+	if len(fields) > 0 {
+		variableForm := huh.NewForm(huh.NewGroup(fields...))
+		if err := variableForm.Run(); err != nil {
+			return
+		}
+	}
+
+	values := make(map[string]string, len(chosen.Variables))
+	for i, v := range chosen.Variables {
+		values[v.Name] = inputs[i]
+	}
+
+	rendered, err := chosen.Render(values)
+	if err != nil {
+		fmt.Println(lipgloss.NewStyle().Faint(true).Render("\nCouldn't render template: " + err.Error()))
+		return
+	}
+
+	goal = rendered
+	returnFormat = chosen.ReturnFormat
+	warnings = chosen.WarningsText()
+}
+
+// runExportCommand implements `prompttogo export`, dumping the full
+// history as a single JSON array on stdout.
+func runExportCommand() {
+	historyPath, err := history.DefaultPath()
+	if err != nil {
+		fmt.Println("Uh oh:", err)
+		os.Exit(1)
+	}
+	store, err := history.NewStore(historyPath)
+	if err != nil {
+		fmt.Println("Uh oh:", err)
+		os.Exit(1)
+	}
+	if err := store.Export(os.Stdout); err != nil {
+		fmt.Println("Uh oh:", err)
+		os.Exit(1)
+	}
+}
+
+// bundledTemplatesDir is where the curated templates shipped with
+// prompttogo live, relative to the working directory the binary is run
+// from.
+const bundledTemplatesDir = "templates"
+
+// newTemplateStore builds a templates.Store over the curated templates
+// directory and the user's installed-template directory.
+func newTemplateStore() (*templates.Store, error) {
+	userDir, err := templates.DefaultUserDir()
+	if err != nil {
+		return nil, err
+	}
+	return templates.NewStore(bundledTemplatesDir, userDir), nil
+}
+
+// runTemplateCommand implements `prompttogo template {list,show,add,install}`.
+func runTemplateCommand(args []string) {
+	store, err := newTemplateStore()
+	if err != nil {
+		fmt.Println("Uh oh:", err)
+		os.Exit(1)
+	}
+
+	if len(args) == 0 {
+		fmt.Println("Usage: prompttogo template {list,show,add,install} [args]")
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "list":
+		all, err := store.List()
+		if err != nil {
+			fmt.Println("Uh oh:", err)
+			os.Exit(1)
+		}
+		for _, t := range all {
+			fmt.Printf("%s — %s\n", t.Name, t.Description)
+		}
+
+	case "show":
+		if len(args) < 2 {
+			fmt.Println("Usage: prompttogo template show <name>")
+			os.Exit(1)
+		}
+		t, err := store.Get(args[1])
+		if err != nil {
+			fmt.Println("Uh oh:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Name: %s\nDescription: %s\nArchetype: %s\nReturn format: %s\nWarnings: %s\nGoal template: %s\n",
+			t.Name, t.Description, t.Archetype, t.ReturnFormat, t.WarningsText(), t.GoalTemplate)
+
+	case "add":
+		if len(args) < 2 {
+			fmt.Println("Usage: prompttogo template add <path> [--force]")
+			os.Exit(1)
+		}
+		if err := store.Add(args[1], hasForceFlag(args[2:])); err != nil {
+			fmt.Println("Uh oh:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Added.")
+
+	case "install":
+		if len(args) < 2 {
+			fmt.Println("Usage: prompttogo template install <url-or-path> [--force]")
+			os.Exit(1)
+		}
+		if err := store.Install(args[1], hasForceFlag(args[2:])); err != nil {
+			fmt.Println("Uh oh:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Installed.")
+
+	default:
+		fmt.Println("Usage: prompttogo template {list,show,add,install} [args]")
+		os.Exit(1)
+	}
+}
+
+// hasForceFlag reports whether "--force" appears among args.
+func hasForceFlag(args []string) bool {
+	for _, a := range args {
+		if a == "--force" {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,117 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// anthropicProvider streams messages from the Anthropic Messages API.
+type anthropicProvider struct {
+	apiKey string
+	model  string
+	client *http.Client
+}
+
+// NewAnthropic returns a Provider backed by the Anthropic Messages API.
+func NewAnthropic(apiKey, model string) Provider {
+	return &anthropicProvider{apiKey: apiKey, model: model, client: http.DefaultClient}
+}
+
+func (p *anthropicProvider) Name() string { return "Anthropic" }
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	Stream    bool               `json:"stream"`
+	MaxTokens int                `json:"max_tokens"`
+	Messages  []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Text string `json:"text"`
+	} `json:"delta"`
+}
+
+func (p *anthropicProvider) Complete(ctx context.Context, prompt Prompt) (<-chan string, error) {
+	body, err := json.Marshal(anthropicRequest{
+		Model:     p.model,
+		Stream:    true,
+		MaxTokens: 4096,
+		Messages: []anthropicMessage{
+			{Role: "user", Content: prompt.Render()},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("anthropic: encode request: %w", err)
+	}
+
+	var resp *http.Response
+	err = withRetry(ctx, 3, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.anthropic.com/v1/messages", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-api-key", p.apiKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+
+		resp, err = p.client.Do(req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 500 {
+			resp.Body.Close()
+			return fmt.Errorf("anthropic: server error %d", resp.StatusCode)
+		}
+		if resp.StatusCode >= 400 {
+			resp.Body.Close()
+			return withRetryStop(fmt.Errorf("anthropic: request failed with status %d", resp.StatusCode))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			var event anthropicEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				continue
+			}
+			if event.Type == "content_block_delta" && event.Delta.Text != "" {
+				select {
+				case out <- event.Delta.Text:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if event.Type == "message_stop" {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,100 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ollamaProvider streams generations from a local Ollama server.
+type ollamaProvider struct {
+	host   string
+	model  string
+	client *http.Client
+}
+
+// NewOllama returns a Provider backed by a local Ollama instance at host
+// (e.g. "http://localhost:11434").
+func NewOllama(host, model string) Provider {
+	return &ollamaProvider{host: strings.TrimSuffix(host, "/"), model: model, client: http.DefaultClient}
+}
+
+func (p *ollamaProvider) Name() string { return "Ollama" }
+
+type ollamaRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+	Stream bool   `json:"stream"`
+}
+
+type ollamaChunk struct {
+	Response string `json:"response"`
+	Done     bool   `json:"done"`
+}
+
+func (p *ollamaProvider) Complete(ctx context.Context, prompt Prompt) (<-chan string, error) {
+	body, err := json.Marshal(ollamaRequest{Model: p.model, Prompt: prompt.Render(), Stream: true})
+	if err != nil {
+		return nil, fmt.Errorf("ollama: encode request: %w", err)
+	}
+
+	var resp *http.Response
+	err = withRetry(ctx, 3, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.host+"/api/generate", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err = p.client.Do(req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 500 {
+			resp.Body.Close()
+			return fmt.Errorf("ollama: server error %d", resp.StatusCode)
+		}
+		if resp.StatusCode >= 400 {
+			resp.Body.Close()
+			return withRetryStop(fmt.Errorf("ollama: request failed with status %d", resp.StatusCode))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			var chunk ollamaChunk
+			if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+				continue
+			}
+			if chunk.Response != "" {
+				select {
+				case out <- chunk.Response:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if chunk.Done {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
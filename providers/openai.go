@@ -0,0 +1,117 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// openAIProvider streams chat completions from the OpenAI API.
+type openAIProvider struct {
+	apiKey string
+	model  string
+	client *http.Client
+}
+
+// NewOpenAI returns a Provider backed by the OpenAI chat completions API.
+func NewOpenAI(apiKey, model string) Provider {
+	return &openAIProvider{apiKey: apiKey, model: model, client: http.DefaultClient}
+}
+
+func (p *openAIProvider) Name() string { return "OpenAI" }
+
+type openAIRequest struct {
+	Model    string          `json:"model"`
+	Stream   bool            `json:"stream"`
+	Messages []openAIMessage `json:"messages"`
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+func (p *openAIProvider) Complete(ctx context.Context, prompt Prompt) (<-chan string, error) {
+	body, err := json.Marshal(openAIRequest{
+		Model:  p.model,
+		Stream: true,
+		Messages: []openAIMessage{
+			{Role: "user", Content: prompt.Render()},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("openai: encode request: %w", err)
+	}
+
+	var resp *http.Response
+	err = withRetry(ctx, 3, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/chat/completions", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+		resp, err = p.client.Do(req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 500 {
+			resp.Body.Close()
+			return fmt.Errorf("openai: server error %d", resp.StatusCode)
+		}
+		if resp.StatusCode >= 400 {
+			resp.Body.Close()
+			return withRetryStop(fmt.Errorf("openai: request failed with status %d", resp.StatusCode))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			if data == "[DONE]" {
+				return
+			}
+			var chunk openAIChunk
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				continue
+			}
+			for _, choice := range chunk.Choices {
+				if choice.Delta.Content != "" {
+					select {
+					case out <- choice.Delta.Content:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
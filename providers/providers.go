@@ -0,0 +1,95 @@
+// Package providers talks to the LLM backends that can actually run a
+// prompt assembled by prompttogo. Each backend implements Provider; the
+// Registry discovers which ones are usable from environment variables so
+// main.go doesn't need to know how any particular API works.
+package providers
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// Prompt is the assembled prompt handed to a provider for completion.
+type Prompt struct {
+	Goal         string
+	ReturnFormat string
+	Warnings     string
+	ContextDump  string
+	Archetype    string
+}
+
+// Render flattens the prompt into the single string most chat APIs expect
+// as a user message.
+func (p Prompt) Render() string {
+	s := p.Goal
+	if p.ReturnFormat != "" {
+		s += "\n\nReturn format:\n" + p.ReturnFormat
+	}
+	if p.Warnings != "" {
+		s += "\n\nWarnings:\n" + p.Warnings
+	}
+	if p.ContextDump != "" {
+		s += "\n\nContext:\n" + p.ContextDump
+	}
+	return s
+}
+
+// Provider is a backend capable of completing a Prompt. Complete streams
+// the response one token (or chunk) at a time on the returned channel,
+// closing it when the response is finished.
+type Provider interface {
+	Name() string
+	Complete(ctx context.Context, prompt Prompt) (<-chan string, error)
+}
+
+// Registry holds the providers that were configured via environment
+// variables and are ready to use.
+type Registry struct {
+	providers map[string]Provider
+}
+
+// NewRegistry inspects the environment for provider credentials/endpoints
+// and returns a Registry containing whichever providers were configured.
+// It never errors: a provider with missing config is simply omitted.
+func NewRegistry() *Registry {
+	r := &Registry{providers: make(map[string]Provider)}
+
+	if key := os.Getenv("OPENAI_API_KEY"); key != "" {
+		r.register(NewOpenAI(key, "gpt-4o-mini"))
+	}
+	if key := os.Getenv("ANTHROPIC_API_KEY"); key != "" {
+		r.register(NewAnthropic(key, "claude-3-5-sonnet-latest"))
+	}
+	if host := os.Getenv("OLLAMA_HOST"); host != "" {
+		r.register(NewOllama(host, "llama3"))
+	}
+
+	return r
+}
+
+func (r *Registry) register(p Provider) {
+	r.providers[p.Name()] = p
+}
+
+// Names returns the configured provider names, in a stable order.
+func (r *Registry) Names() []string {
+	order := []string{"OpenAI", "Anthropic", "Ollama"}
+	names := make([]string, 0, len(r.providers))
+	for _, name := range order {
+		if _, ok := r.providers[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Get looks up a configured provider by name.
+func (r *Registry) Get(name string) (Provider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// ErrNoProviders is returned by callers when the registry has nothing
+// configured and the caller isn't willing to fall back to --dry-run.
+var ErrNoProviders = fmt.Errorf("no providers configured: set OPENAI_API_KEY, ANTHROPIC_API_KEY, or OLLAMA_HOST")
@@ -0,0 +1,49 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// permanentError wraps an error that withRetry should not retry, such as a
+// 4xx response that a backoff will never fix.
+type permanentError struct{ err error }
+
+func (p permanentError) Error() string { return p.err.Error() }
+func (p permanentError) Unwrap() error { return p.err }
+
+// withRetryStop marks err as permanent so withRetry surfaces it immediately
+// instead of backing off and trying again.
+func withRetryStop(err error) error {
+	return permanentError{err: err}
+}
+
+// withRetry runs fn with exponential backoff (starting at 500ms, doubling,
+// capped at attempts tries) and returns the first success. It gives up
+// early if ctx is cancelled or fn returns a permanent error.
+func withRetry(ctx context.Context, attempts int, fn func() error) error {
+	var err error
+	backoff := 500 * time.Millisecond
+
+	for i := 0; i < attempts; i++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		var perm permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+		if i == attempts-1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return err
+}